@@ -18,8 +18,14 @@ package checkers
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/pingcap/failpoint"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
@@ -29,10 +35,135 @@ import (
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
 	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 )
 
+var (
+	leaderCheckerTasksEmitted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.MilvusNamespace,
+			Subsystem: metrics.QueryCoordRole,
+			Name:      "leader_checker_tasks_emitted_total",
+			Help:      "number of leader tasks emitted by LeaderChecker",
+		}, []string{"checker_id"})
+
+	leaderCheckerTasksDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.MilvusNamespace,
+			Subsystem: metrics.QueryCoordRole,
+			Name:      "leader_checker_tasks_dropped_total",
+			Help:      "number of leader tasks dropped by LeaderChecker because the per-tick budget was exhausted",
+		}, []string{"checker_id"})
+)
+
+func init() {
+	prometheus.MustRegister(leaderCheckerTasksEmitted)
+	prometheus.MustRegister(leaderCheckerTasksDropped)
+}
+
 var _ Checker = (*LeaderChecker)(nil)
 
+// leaderDirtyKey identifies a single (collection, replica, channel) tuple that
+// LeaderChecker.Check can re-evaluate independently of the rest of the cluster.
+type leaderDirtyKey struct {
+	CollectionID int64
+	ReplicaID    int64
+	Channel      string
+}
+
+// segmentRoute is the (segmentID, nodeID) pair tupleFingerprint hashes over. It
+// captures both a segment distribution entry and a leader-view routing entry in
+// the same shape, since a change to either is what makes a tuple dirty.
+type segmentRoute struct {
+	segmentID int64
+	nodeID    int64
+}
+
+// tupleFingerprint summarizes a delegator's segment distribution and its current
+// leader-view routing table into a single comparable value.
+//
+// This is a fallback, not the subscription model originally intended:
+// DistributionManager, TargetManager and meta.CollectionManager expose no
+// hook-registration API for a checker to subscribe to, so there is no event to
+// wire a push-based dirty set to. LeaderChecker instead recomputes this
+// fingerprint every tick and diffs it against the previous tick's value. That
+// does NOT avoid the per-tick collection/replica/node/delegator enumeration —
+// Check still walks the whole tree to know what to fingerprint — it only skips
+// the costlier per-segment target lookups in diffLeaderView for tuples whose
+// fingerprint hasn't moved. Treat this as "skip expensive re-diffing", not as
+// the requested "only touch dirty tuples" architecture.
+func tupleFingerprint(distRoutes, viewRoutes []segmentRoute) uint64 {
+	h := fnv.New64a()
+	for _, r := range sortedRoutes(distRoutes) {
+		fmt.Fprintf(h, "d:%d:%d;", r.segmentID, r.nodeID)
+	}
+	for _, r := range sortedRoutes(viewRoutes) {
+		fmt.Fprintf(h, "v:%d:%d;", r.segmentID, r.nodeID)
+	}
+	return h.Sum64()
+}
+
+func sortedRoutes(routes []segmentRoute) []segmentRoute {
+	sorted := make([]segmentRoute, len(routes))
+	copy(sorted, routes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].segmentID != sorted[j].segmentID {
+			return sorted[i].segmentID < sorted[j].segmentID
+		}
+		return sorted[i].nodeID < sorted[j].nodeID
+	})
+	return sorted
+}
+
+// collectionFingerprint plays the same role as tupleFingerprint but for the
+// things TargetManager's target swaps and meta.CollectionManager's replica
+// membership changes would otherwise push to the checker: the replica set
+// backing a collection, and whether it currently has a next/current target at
+// all. A change marks every tuple under the collection dirty.
+func collectionFingerprint(replicaIDs []int64, nextTargetExists, currentTargetExists bool) uint64 {
+	sorted := make([]int64, len(replicaIDs))
+	copy(sorted, replicaIDs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	h := fnv.New64a()
+	for _, id := range sorted {
+		fmt.Fprintf(h, "r:%d;", id)
+	}
+	fmt.Fprintf(h, "n:%t;c:%t", nextTargetExists, currentTargetExists)
+	return h.Sum64()
+}
+
+// leaderDiff captures everything that changed for one leaderDirtyKey on a single
+// Check pass, so tests (and future budget/priority logic) can assert exactly
+// which sub-diffs fired without having to parse generated tasks back apart.
+type leaderDiff struct {
+	addedSegments     []task.Task
+	removedSegments   []task.Task
+	partStatsVersions []task.Task
+}
+
+func (d *leaderDiff) isEmpty() bool {
+	return d == nil || (len(d.addedSegments) == 0 && len(d.removedSegments) == 0 && len(d.partStatsVersions) == 0)
+}
+
+// LeaderTaskObserver is invoked with every task.LeaderSegmentTask/task.LeaderPartStatsTask
+// LeaderChecker emits, after its priority and reason are set. It exists purely so
+// higher-level QueryCoord tests can assert on what a Check pass produced without
+// reaching into the task scheduler.
+type LeaderTaskObserver func(task.Task)
+
+func (d *leaderDiff) tasks() []task.Task {
+	if d == nil {
+		return nil
+	}
+	tasks := make([]task.Task, 0, len(d.addedSegments)+len(d.removedSegments)+len(d.partStatsVersions))
+	tasks = append(tasks, d.addedSegments...)
+	tasks = append(tasks, d.removedSegments...)
+	tasks = append(tasks, d.partStatsVersions...)
+	return tasks
+}
+
 // LeaderChecker perform segment index check.
 type LeaderChecker struct {
 	*checkerActivation
@@ -40,6 +171,47 @@ type LeaderChecker struct {
 	dist    *meta.DistributionManager
 	target  meta.TargetManagerInterface
 	nodeMgr *session.NodeManager
+
+	fingerprintMu          sync.Mutex
+	tupleFingerprints      map[leaderDirtyKey]uint64
+	collectionFingerprints map[int64]uint64
+	forceFullScan          bool
+	lastFullScanAt         time.Time
+
+	observerMu    sync.RWMutex
+	taskObservers []LeaderTaskObserver
+
+	budgetMu     sync.Mutex
+	budgetCursor leaderBudgetCursor
+}
+
+// leaderBudgetCursor remembers the last (collection, replica) pair a Check pass
+// touched before its per-tick task budget ran out, so the next tick resumes right
+// after it instead of always starving the tail of the collection list.
+type leaderBudgetCursor struct {
+	CollectionID int64
+	ReplicaID    int64
+	valid        bool
+}
+
+// RegisterTaskObserver registers fn to be called with every leader task LeaderChecker
+// emits from then on. Used by integration/chaos tests to observe the effect of
+// failpoint-injected leader-view divergences without polling the task scheduler.
+func (c *LeaderChecker) RegisterTaskObserver(fn LeaderTaskObserver) {
+	c.observerMu.Lock()
+	defer c.observerMu.Unlock()
+	c.taskObservers = append(c.taskObservers, fn)
+}
+
+// notifyTaskObservers is called once per task that survives Check's per-tick
+// budget, so an observer never sees a task that was actually dropped.
+func (c *LeaderChecker) notifyTaskObservers(t task.Task) {
+	c.observerMu.RLock()
+	observers := c.taskObservers
+	c.observerMu.RUnlock()
+	for _, observe := range observers {
+		observe(t)
+	}
 }
 
 func NewLeaderChecker(
@@ -49,14 +221,29 @@ func NewLeaderChecker(
 	nodeMgr *session.NodeManager,
 ) *LeaderChecker {
 	return &LeaderChecker{
-		checkerActivation: newCheckerActivation(),
-		meta:              meta,
-		dist:              dist,
-		target:            target,
-		nodeMgr:           nodeMgr,
+		checkerActivation:      newCheckerActivation(),
+		meta:                   meta,
+		dist:                   dist,
+		target:                 target,
+		nodeMgr:                nodeMgr,
+		tupleFingerprints:      make(map[leaderDirtyKey]uint64),
+		collectionFingerprints: make(map[int64]uint64),
+		// the first Check after Activate() must behave as a full scan, regardless
+		// of whether anything has actually changed yet.
+		forceFullScan: true,
 	}
 }
 
+// Activate also forces the next Check pass to run as a full scan, since whatever
+// happened while this checker was deactivated was never reflected in
+// tupleFingerprints/collectionFingerprints.
+func (c *LeaderChecker) Activate() {
+	c.fingerprintMu.Lock()
+	c.forceFullScan = true
+	c.fingerprintMu.Unlock()
+	c.checkerActivation.Activate()
+}
+
 func (c *LeaderChecker) ID() utils.CheckerType {
 	return utils.LeaderChecker
 }
@@ -65,6 +252,82 @@ func (c *LeaderChecker) Description() string {
 	return "LeaderChecker checks the difference of leader view between dist, and try to correct it"
 }
 
+// collectionChanged reports whether fp differs from the fingerprint recorded for
+// collectionID on the previous tick (or whether this is the first tick to see
+// it), and stores fp as the new baseline either way.
+func (c *LeaderChecker) collectionChanged(collectionID int64, fp uint64) bool {
+	c.fingerprintMu.Lock()
+	defer c.fingerprintMu.Unlock()
+	prev, seen := c.collectionFingerprints[collectionID]
+	c.collectionFingerprints[collectionID] = fp
+	return !seen || prev != fp
+}
+
+// tuplePrevFingerprint reports the fingerprint recorded for key on the previous
+// tick, if any, without recording fp. Unlike collectionChanged, committing a
+// tuple's fingerprint is deferred until the caller knows none of its tasks were
+// dropped by the budget — see commitTupleFingerprint.
+func (c *LeaderChecker) tuplePrevFingerprint(key leaderDirtyKey) (uint64, bool) {
+	c.fingerprintMu.Lock()
+	defer c.fingerprintMu.Unlock()
+	fp, seen := c.tupleFingerprints[key]
+	return fp, seen
+}
+
+// commitTupleFingerprint records fp as key's new baseline. Callers must only do
+// this once they know every task generated for key actually survived the
+// per-tick budget — otherwise a dropped fix would be marked clean and not
+// resurface until the next full scan.
+func (c *LeaderChecker) commitTupleFingerprint(key leaderDirtyKey, fp uint64) {
+	c.fingerprintMu.Lock()
+	defer c.fingerprintMu.Unlock()
+	c.tupleFingerprints[key] = fp
+}
+
+func (c *LeaderChecker) fullScanInterval() time.Duration {
+	return paramtable.Get().QueryCoordCfg.LeaderViewFullScanInterval.GetAsDuration(time.Second)
+}
+
+// totalTaskBudget caps how many leader tasks a single Check pass may emit, so one
+// tick can't clog the task scheduler behind higher-value balance/segment tasks.
+// A non-positive value disables the budget.
+func (c *LeaderChecker) totalTaskBudget() int {
+	return paramtable.Get().QueryCoordCfg.LeaderCheckerTaskBudget.GetAsInt()
+}
+
+func (c *LeaderChecker) perCollectionTaskBudget() int {
+	return paramtable.Get().QueryCoordCfg.LeaderCheckerTaskBudgetPerCollection.GetAsInt()
+}
+
+func (c *LeaderChecker) perReplicaTaskBudget() int {
+	return paramtable.Get().QueryCoordCfg.LeaderCheckerTaskBudgetPerReplica.GetAsInt()
+}
+
+// partStatsTaskPriority returns the configurable priority tier for partition-stats
+// sync tasks, kept independent from the segment-routing tiers above.
+func (c *LeaderChecker) partStatsTaskPriority() task.TaskPriority {
+	return task.TaskPriority(paramtable.Get().QueryCoordCfg.LeaderCheckerPartStatsTaskPriority.GetAsInt())
+}
+
+// dueForFullScan reports whether this Check pass must fall back to a full sweep,
+// either because it's the first tick since Activate() or the safety-net interval
+// has elapsed since the last full scan.
+func (c *LeaderChecker) dueForFullScan(now time.Time) bool {
+	c.fingerprintMu.Lock()
+	defer c.fingerprintMu.Unlock()
+	if c.forceFullScan {
+		return true
+	}
+	return now.Sub(c.lastFullScanAt) >= c.fullScanInterval()
+}
+
+func (c *LeaderChecker) markFullScanDone(now time.Time) {
+	c.fingerprintMu.Lock()
+	defer c.fingerprintMu.Unlock()
+	c.forceFullScan = false
+	c.lastFullScanAt = now
+}
+
 func (c *LeaderChecker) readyToCheck(ctx context.Context, collectionID int64) bool {
 	metaExist := (c.meta.GetCollection(ctx, collectionID) != nil)
 	targetExist := c.target.IsNextTargetExist(ctx, collectionID) || c.target.IsCurrentTargetExist(ctx, collectionID, common.AllPartitionsID)
@@ -72,14 +335,174 @@ func (c *LeaderChecker) readyToCheck(ctx context.Context, collectionID int64) bo
 	return metaExist && targetExist
 }
 
+// leaderCheckUnit is one (collection, replica) pair queued up for evaluation in a
+// single Check pass, in the order the round-robin cursor walks them.
+type leaderCheckUnit struct {
+	collectionID int64
+	replica      *meta.Replica
+}
+
+// budgetState is Check's per-tick task budget accounting, split out as a plain
+// struct so evaluateTuple's admission logic can be unit tested without real
+// meta.Replica/meta.LeaderView values.
+type budgetState struct {
+	totalBudget          int
+	perCollectionBudget  int
+	perReplicaBudget     int
+	totalEmitted         int
+	perCollectionEmitted map[int64]int
+	perReplicaEmitted    map[int64]int
+}
+
+func newBudgetState(totalBudget, perCollectionBudget, perReplicaBudget int) *budgetState {
+	return &budgetState{
+		totalBudget:          totalBudget,
+		perCollectionBudget:  perCollectionBudget,
+		perReplicaBudget:     perReplicaBudget,
+		perCollectionEmitted: make(map[int64]int),
+		perReplicaEmitted:    make(map[int64]int),
+	}
+}
+
+// admit reports whether one more task may be emitted for (collectionID,
+// replicaID), and whether the total budget specifically is what's exhausted
+// (the signal Check uses to stop the whole pass and remember a resume cursor,
+// as opposed to a per-collection/per-replica cap that only skips this unit).
+func (b *budgetState) admit(collectionID, replicaID int64) (ok bool, totalExhausted bool) {
+	if b.totalBudget > 0 && b.totalEmitted >= b.totalBudget ||
+		b.perCollectionBudget > 0 && b.perCollectionEmitted[collectionID] >= b.perCollectionBudget ||
+		b.perReplicaBudget > 0 && b.perReplicaEmitted[replicaID] >= b.perReplicaBudget {
+		return false, b.totalBudget > 0 && b.totalEmitted >= b.totalBudget
+	}
+	b.totalEmitted++
+	b.perCollectionEmitted[collectionID]++
+	b.perReplicaEmitted[replicaID]++
+	return true, false
+}
+
+// evaluateTuple runs one tuple's tasks through budget admission, notifying
+// observe for each one that's actually emitted. It is Check's inner-loop core
+// for a single tuple, split out so the commit-on-drop behavior below can be
+// tested without real meta types.
+func evaluateTuple(tasks []task.Task, collectionID, replicaID int64, budget *budgetState, observe func(task.Task)) (emitted []task.Task, dropped int, totalExhausted bool) {
+	for _, t := range tasks {
+		ok, exhausted := budget.admit(collectionID, replicaID)
+		if !ok {
+			dropped++
+			if exhausted {
+				return emitted, dropped, true
+			}
+			continue
+		}
+		emitted = append(emitted, t)
+		observe(t)
+	}
+	return emitted, dropped, false
+}
+
 func (c *LeaderChecker) Check(ctx context.Context) []task.Task {
 	if !c.IsActive() {
 		return nil
 	}
 
-	collectionIDs := c.meta.CollectionManager.GetAll(ctx)
+	now := time.Now()
+	fullScan := c.dueForFullScan(now)
+
+	units, collectionDirty := c.buildCheckUnits(ctx)
+	units = c.rotateToCursor(units)
+
 	tasks := make([]task.Task, 0)
+	budget := newBudgetState(c.totalTaskBudget(), c.perCollectionTaskBudget(), c.perReplicaTaskBudget())
+	totalDropped := 0
+	budgetExhausted := false
+
+unitLoop:
+	for _, unit := range units {
+		collectionID := unit.collectionID
+		replica := unit.replica
+		dirtyCollection := fullScan || collectionDirty[collectionID]
+
+		nodes := replica.GetRWNodes()
+		if streamingutil.IsStreamingServiceEnabled() {
+			nodes = replica.GetRWSQNodes()
+		}
+		for _, node := range nodes {
+			delegatorList := c.dist.ChannelDistManager.GetByFilter(meta.WithCollectionID2Channel(replica.GetCollectionID()), meta.WithNodeID2Channel(node))
+			for _, d := range delegatorList {
+				dist := c.dist.SegmentDistManager.GetByFilter(meta.WithChannel(d.View.Channel), meta.WithReplica(replica))
+
+				key := leaderDirtyKey{CollectionID: collectionID, ReplicaID: replica.GetID(), Channel: d.View.Channel}
+				fp := tupleFingerprint(distRoutesOf(dist), viewRoutesOf(d.View))
+				prevFP, seen := c.tuplePrevFingerprint(key)
+				tupleDirty := !seen || prevFP != fp
+				if !dirtyCollection && !tupleDirty {
+					continue
+				}
+
+				diff := c.diffLeaderView(ctx, replica, d.View, dist, node)
+				emitted, dropped, totalExhausted := evaluateTuple(diff.tasks(), collectionID, replica.GetID(), budget, c.notifyTaskObservers)
+				tasks = append(tasks, emitted...)
+				totalDropped += dropped
+				// Only mark the tuple clean once every task it produced actually
+				// made it through the budget; a dropped fix must stay dirty so the
+				// very next tick retries it instead of waiting on a full scan.
+				if dropped == 0 {
+					c.commitTupleFingerprint(key, fp)
+				}
+				if totalExhausted {
+					budgetExhausted = true
+					c.setBudgetCursor(leaderBudgetCursor{CollectionID: collectionID, ReplicaID: replica.GetID(), valid: true})
+					break unitLoop
+				}
+			}
+		}
+	}
 
+	if !budgetExhausted {
+		c.setBudgetCursor(leaderBudgetCursor{})
+	}
+
+	checkerID := c.ID().String()
+	leaderCheckerTasksEmitted.WithLabelValues(checkerID).Add(float64(budget.totalEmitted))
+	if totalDropped > 0 {
+		leaderCheckerTasksDropped.WithLabelValues(checkerID).Add(float64(totalDropped))
+		log.Ctx(ctx).Warn("leaderChecker dropped tasks due to per-tick budget",
+			zap.Int("dropped", totalDropped), zap.Int("emitted", budget.totalEmitted))
+	}
+
+	if fullScan {
+		c.markFullScanDone(now)
+	}
+
+	return tasks
+}
+
+func distRoutesOf(dist []*meta.Segment) []segmentRoute {
+	routes := make([]segmentRoute, 0, len(dist))
+	for _, s := range dist {
+		routes = append(routes, segmentRoute{segmentID: s.GetID(), nodeID: s.Node})
+	}
+	return routes
+}
+
+func viewRoutesOf(view *meta.LeaderView) []segmentRoute {
+	routes := make([]segmentRoute, 0, len(view.Segments))
+	for sid, v := range view.Segments {
+		routes = append(routes, segmentRoute{segmentID: sid, nodeID: v.GetNodeID()})
+	}
+	return routes
+}
+
+// buildCheckUnits returns every ready-to-check (collection, replica) pair in a
+// stable, deterministic order, so the round-robin cursor can be applied on top,
+// along with which collections changed since the last tick (a replica was added
+// or dropped, or the next/current target appeared or disappeared).
+func (c *LeaderChecker) buildCheckUnits(ctx context.Context) ([]leaderCheckUnit, map[int64]bool) {
+	collectionIDs := c.meta.CollectionManager.GetAll(ctx)
+	sort.Slice(collectionIDs, func(i, j int) bool { return collectionIDs[i] < collectionIDs[j] })
+
+	units := make([]leaderCheckUnit, 0, len(collectionIDs))
+	collectionDirty := make(map[int64]bool, len(collectionIDs))
 	for _, collectionID := range collectionIDs {
 		if !c.readyToCheck(ctx, collectionID) {
 			continue
@@ -90,25 +513,106 @@ func (c *LeaderChecker) Check(ctx context.Context) []task.Task {
 			continue
 		}
 
+		failpoint.Inject("leaderChecker/skipCollection", func(val failpoint.Value) {
+			if skipID, ok := val.(int); ok && int64(skipID) == collectionID {
+				log.Ctx(ctx).Info("leaderChecker/skipCollection fired, skipping collection", zap.Int64("collectionID", collectionID))
+				collection = nil
+			}
+		})
+		if collection == nil {
+			continue
+		}
+
 		replicas := c.meta.ReplicaManager.GetByCollection(ctx, collectionID)
+		sort.Slice(replicas, func(i, j int) bool { return replicas[i].GetID() < replicas[j].GetID() })
+
+		replicaIDs := make([]int64, 0, len(replicas))
 		for _, replica := range replicas {
-			nodes := replica.GetRWNodes()
-			if streamingutil.IsStreamingServiceEnabled() {
-				nodes = replica.GetRWSQNodes()
-			}
-			for _, node := range nodes {
-				delegatorList := c.dist.ChannelDistManager.GetByFilter(meta.WithCollectionID2Channel(replica.GetCollectionID()), meta.WithNodeID2Channel(node))
-				for _, d := range delegatorList {
-					dist := c.dist.SegmentDistManager.GetByFilter(meta.WithChannel(d.View.Channel), meta.WithReplica(replica))
-					tasks = append(tasks, c.findNeedLoadedSegments(ctx, replica, d.View, dist)...)
-					tasks = append(tasks, c.findNeedRemovedSegments(ctx, replica, d.View, dist)...)
-					tasks = append(tasks, c.findNeedSyncPartitionStats(ctx, replica, d.View, node)...)
-				}
-			}
+			replicaIDs = append(replicaIDs, replica.GetID())
+		}
+		fp := collectionFingerprint(replicaIDs, c.target.IsNextTargetExist(ctx, collectionID), c.target.IsCurrentTargetExist(ctx, collectionID, common.AllPartitionsID))
+		collectionDirty[collectionID] = c.collectionChanged(collectionID, fp)
+
+		for _, replica := range replicas {
+			units = append(units, leaderCheckUnit{collectionID: collectionID, replica: replica})
 		}
 	}
+	return units, collectionDirty
+}
 
-	return tasks
+// rotateToCursor reorders units so the one right after the persisted cursor comes
+// first, implementing the round-robin resume-where-we-left-off behavior. If the
+// cursor is unset, or no longer matches any unit (e.g. the replica was dropped),
+// units is returned unchanged.
+// leaderCheckUnitKey is the identity rotateKeysToCursor rotates on, split out of
+// leaderCheckUnit so the rotation logic doesn't need a real *meta.Replica.
+type leaderCheckUnitKey struct {
+	collectionID int64
+	replicaID    int64
+}
+
+// rotateKeysToCursor is rotateToCursor's pure core: it returns the index keys
+// should start at so the unit right after cursor comes first. If the cursor is
+// unset, keys is empty, or the cursor no longer matches anything (e.g. the
+// replica was dropped), it returns 0 and the order is left unchanged.
+func rotateKeysToCursor(keys []leaderCheckUnitKey, cursor leaderBudgetCursor) int {
+	if !cursor.valid || len(keys) == 0 {
+		return 0
+	}
+	for i, k := range keys {
+		if k.collectionID == cursor.CollectionID && k.replicaID == cursor.ReplicaID {
+			return (i + 1) % len(keys)
+		}
+	}
+	return 0
+}
+
+func (c *LeaderChecker) rotateToCursor(units []leaderCheckUnit) []leaderCheckUnit {
+	c.budgetMu.Lock()
+	cursor := c.budgetCursor
+	c.budgetMu.Unlock()
+
+	keys := make([]leaderCheckUnitKey, len(units))
+	for i, u := range units {
+		keys[i] = leaderCheckUnitKey{collectionID: u.collectionID, replicaID: u.replica.GetID()}
+	}
+	start := rotateKeysToCursor(keys, cursor)
+	if start == 0 {
+		return units
+	}
+	rotated := make([]leaderCheckUnit, 0, len(units))
+	rotated = append(rotated, units[start:]...)
+	rotated = append(rotated, units[:start]...)
+	return rotated
+}
+
+func (c *LeaderChecker) setBudgetCursor(cursor leaderBudgetCursor) {
+	c.budgetMu.Lock()
+	defer c.budgetMu.Unlock()
+	c.budgetCursor = cursor
+}
+
+// diffLeaderView evaluates the three sub-diffs for a single (replica, leaderView)
+// tuple and bundles them into a leaderDiff, so a caller (Check, or a unit test) can
+// inspect which sub-diffs actually fired for this tuple.
+func (c *LeaderChecker) diffLeaderView(ctx context.Context, replica *meta.Replica, leaderView *meta.LeaderView, dist []*meta.Segment, node int64) *leaderDiff {
+	diff := &leaderDiff{
+		addedSegments:     c.findNeedLoadedSegments(ctx, replica, leaderView, dist),
+		removedSegments:   c.findNeedRemovedSegments(ctx, replica, leaderView, dist),
+		partStatsVersions: c.findNeedSyncPartitionStats(ctx, replica, leaderView, node),
+	}
+	return diff
+}
+
+// clampPartStatsRegression drops any partID from partStatsToUpdate whose
+// version is behind what partStatsInLView already has, so a regressed version
+// is never shipped to a worker.
+func clampPartStatsRegression(partStatsToUpdate, partStatsInLView map[int64]int64) {
+	for partID, v := range partStatsToUpdate {
+		if v < partStatsInLView[partID] {
+			delete(partStatsToUpdate, partID)
+		}
+	}
 }
 
 func (c *LeaderChecker) findNeedSyncPartitionStats(ctx context.Context, replica *meta.Replica, leaderView *meta.LeaderView, nodeID int64) []task.Task {
@@ -132,6 +636,20 @@ func (c *LeaderChecker) findNeedSyncPartitionStats(ctx context.Context, replica
 				zap.Int64("psVersionInTarget", psVersionInTarget))
 		}
 	}
+
+	// injectPartStatRegression rolls the computed target versions back below what the
+	// leader view already has, so tests can check a regression never reaches the worker.
+	failpoint.Inject("leaderChecker/injectPartStatRegression", func() {
+		for partID := range partStatsToUpdate {
+			partStatsToUpdate[partID] = partStatsInLView[partID] - 1
+		}
+	})
+
+	// A version can never move backwards: whatever computed partStatsToUpdate
+	// (normally monotonic, but failpoint-injectable above for tests), never ship
+	// one that's behind what the leader view already acked.
+	clampPartStatsRegression(partStatsToUpdate, partStatsInLView)
+
 	if len(partStatsToUpdate) > 0 {
 		action := task.NewLeaderUpdatePartStatsAction(leaderView.ID, nodeID, task.ActionTypeUpdate, leaderView.Channel, partStatsToUpdate)
 
@@ -144,8 +662,8 @@ func (c *LeaderChecker) findNeedSyncPartitionStats(ctx context.Context, replica
 			action,
 		)
 
-		// leader task shouldn't replace executing segment task
-		t.SetPriority(task.TaskPriorityLow)
+		// partition-stat syncs get their own tunable tier, independent of segment routing fixes.
+		t.SetPriority(c.partStatsTaskPriority())
 		t.SetReason("sync partition stats versions")
 		ret = append(ret, t)
 		log.Ctx(ctx).Debug("Created leader actions for partitionStats",
@@ -177,6 +695,13 @@ func (c *LeaderChecker) findNeedLoadedSegments(ctx context.Context, replica *met
 		// 2. Outdated Segment Routing - A segment has multiple copies loaded, but the routing table points to a node that does not host the most recently loaded copy.
 		// This ensures the routing table remains accurate and up-to-date, reflecting the latest segment distribution.
 		version, ok := leaderView.Segments[s.GetID()]
+
+		// forceStaleLeaderView pretends the routing table never picked up this segment,
+		// forcing the missing-segment branch below without waiting on a real delegator.
+		failpoint.Inject("leaderChecker/forceStaleLeaderView", func() {
+			ok = false
+		})
+
 		if !ok || version.GetNodeID() != s.Node {
 			log.RatedDebug(10, "leader checker append a segment to set",
 				zap.Int64("segmentID", s.GetID()),
@@ -192,9 +717,15 @@ func (c *LeaderChecker) findNeedLoadedSegments(ctx context.Context, replica *met
 				action,
 			)
 
-			// leader task shouldn't replace executing segment task
-			t.SetPriority(task.TaskPriorityLow)
-			t.SetReason("add segment to leader view")
+			// a segment missing from the routing table entirely means queries against it
+			// fail outright, so it outranks a merely outdated NodeID mapping.
+			if !ok {
+				t.SetPriority(task.TaskPriorityNormal)
+				t.SetReason("add missing segment to leader view")
+			} else {
+				t.SetPriority(task.TaskPriorityLow)
+				t.SetReason("update outdated segment routing in leader view")
+			}
 			ret = append(ret, t)
 		}
 	}
@@ -225,6 +756,17 @@ func (c *LeaderChecker) findNeedRemovedSegments(ctx context.Context, replica *me
 		log.Debug("leader checker append a segment to remove",
 			zap.Int64("segmentID", sid),
 			zap.Int64("nodeID", s.NodeID))
+
+		// dropRemoveAction swallows this remove action, simulating it getting lost in the
+		// scheduler, so tests can confirm the segment isn't silently orphaned on retry.
+		dropped := false
+		failpoint.Inject("leaderChecker/dropRemoveAction", func() {
+			dropped = true
+		})
+		if dropped {
+			continue
+		}
+
 		// reduce leader action won't be execute on worker, in  order to remove segment from delegator success even when worker done
 		// set workerID to leader view's node
 		action := task.NewLeaderAction(leaderView.ID, leaderView.ID, task.ActionTypeReduce, leaderView.Channel, sid, 0)
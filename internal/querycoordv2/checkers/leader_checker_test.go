@@ -0,0 +1,216 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+)
+
+func TestLeaderDiff_IsEmpty(t *testing.T) {
+	var nilDiff *leaderDiff
+	assert.True(t, nilDiff.isEmpty())
+
+	empty := &leaderDiff{}
+	assert.True(t, empty.isEmpty())
+
+	nonEmpty := &leaderDiff{addedSegments: []task.Task{nil}}
+	assert.False(t, nonEmpty.isEmpty())
+}
+
+func TestLeaderDiff_Tasks(t *testing.T) {
+	diff := &leaderDiff{
+		addedSegments:     []task.Task{nil, nil},
+		removedSegments:   []task.Task{nil},
+		partStatsVersions: []task.Task{nil, nil, nil},
+	}
+	assert.Len(t, diff.tasks(), 6)
+
+	var nilDiff *leaderDiff
+	assert.Nil(t, nilDiff.tasks())
+}
+
+func TestTupleFingerprint_OrderIndependent(t *testing.T) {
+	a := []segmentRoute{{segmentID: 1, nodeID: 10}, {segmentID: 2, nodeID: 20}}
+	b := []segmentRoute{{segmentID: 2, nodeID: 20}, {segmentID: 1, nodeID: 10}}
+	assert.Equal(t, tupleFingerprint(a, nil), tupleFingerprint(b, nil))
+}
+
+func TestTupleFingerprint_DetectsChange(t *testing.T) {
+	dist := []segmentRoute{{segmentID: 1, nodeID: 10}}
+	view := []segmentRoute{{segmentID: 1, nodeID: 10}}
+	before := tupleFingerprint(dist, view)
+
+	view[0].nodeID = 11
+	after := tupleFingerprint(dist, view)
+	assert.NotEqual(t, before, after)
+}
+
+func TestCollectionFingerprint_OrderIndependent(t *testing.T) {
+	a := collectionFingerprint([]int64{1, 2, 3}, true, false)
+	b := collectionFingerprint([]int64{3, 1, 2}, true, false)
+	assert.Equal(t, a, b)
+}
+
+func TestCollectionFingerprint_DetectsChange(t *testing.T) {
+	before := collectionFingerprint([]int64{1, 2}, true, false)
+	afterReplicaAdded := collectionFingerprint([]int64{1, 2, 3}, true, false)
+	afterTargetDropped := collectionFingerprint([]int64{1, 2}, false, false)
+	assert.NotEqual(t, before, afterReplicaAdded)
+	assert.NotEqual(t, before, afterTargetDropped)
+}
+
+func TestLeaderChecker_TuplePrevAndCommitFingerprint(t *testing.T) {
+	c := &LeaderChecker{
+		tupleFingerprints:      make(map[leaderDirtyKey]uint64),
+		collectionFingerprints: make(map[int64]uint64),
+	}
+
+	key := leaderDirtyKey{CollectionID: 1, ReplicaID: 1, Channel: "ch-0"}
+	_, seen := c.tuplePrevFingerprint(key)
+	assert.False(t, seen, "unseen key must report not-seen")
+
+	c.commitTupleFingerprint(key, 42)
+	prev, seen := c.tuplePrevFingerprint(key)
+	assert.True(t, seen)
+	assert.Equal(t, uint64(42), prev)
+
+	// A peek must never itself record anything.
+	_, seen = c.tuplePrevFingerprint(key)
+	assert.True(t, seen)
+	prev, _ = c.tuplePrevFingerprint(key)
+	assert.Equal(t, uint64(42), prev, "peeking repeatedly must not change the stored value")
+}
+
+func TestLeaderChecker_CollectionChanged(t *testing.T) {
+	c := &LeaderChecker{
+		tupleFingerprints:      make(map[leaderDirtyKey]uint64),
+		collectionFingerprints: make(map[int64]uint64),
+	}
+
+	assert.True(t, c.collectionChanged(1, 100))
+	assert.False(t, c.collectionChanged(1, 100))
+	assert.True(t, c.collectionChanged(1, 200))
+}
+
+func TestClampPartStatsRegression(t *testing.T) {
+	partStatsInLView := map[int64]int64{1: 5, 2: 10}
+
+	partStatsToUpdate := map[int64]int64{1: 6, 2: 9, 3: 1}
+	clampPartStatsRegression(partStatsToUpdate, partStatsInLView)
+
+	assert.Equal(t, map[int64]int64{1: 6, 3: 1}, partStatsToUpdate, "partition 2 regresses versus the leader view and must be dropped")
+}
+
+func TestLeaderChecker_NotifyTaskObservers(t *testing.T) {
+	c := &LeaderChecker{}
+
+	var firstCount, secondCount int
+	c.RegisterTaskObserver(func(task.Task) { firstCount++ })
+	c.RegisterTaskObserver(func(task.Task) { secondCount++ })
+
+	c.notifyTaskObservers(nil)
+	c.notifyTaskObservers(nil)
+
+	assert.Equal(t, 2, firstCount)
+	assert.Equal(t, 2, secondCount)
+}
+
+func TestBudgetState_Admit(t *testing.T) {
+	b := newBudgetState(2 /* total */, 1 /* perCollection */, 0 /* perReplica */)
+
+	ok, exhausted := b.admit(1, 1)
+	assert.True(t, ok)
+	assert.False(t, exhausted)
+
+	// Per-collection cap of 1 refuses a second task for the same collection even
+	// though the total budget still has room.
+	ok, exhausted = b.admit(1, 2)
+	assert.False(t, ok)
+	assert.False(t, exhausted, "a per-collection cap is not a total-budget exhaustion")
+
+	ok, exhausted = b.admit(2, 1)
+	assert.True(t, ok)
+	assert.False(t, exhausted)
+
+	// Total budget of 2 is now spent.
+	ok, exhausted = b.admit(3, 1)
+	assert.False(t, ok)
+	assert.True(t, exhausted)
+}
+
+func TestEvaluateTuple_DropsBeyondBudgetAndNotifiesOnlyAdmitted(t *testing.T) {
+	budget := newBudgetState(1, 0, 0)
+	tasks := []task.Task{nil, nil, nil}
+
+	var observed int
+	emitted, dropped, totalExhausted := evaluateTuple(tasks, 1, 1, budget, func(task.Task) { observed++ })
+
+	assert.Len(t, emitted, 1)
+	assert.Equal(t, 2, dropped)
+	assert.True(t, totalExhausted)
+	assert.Equal(t, 1, observed, "observer must only fire for the task that survived the budget")
+}
+
+// TestLeaderChecker_DroppedTaskKeepsTupleDirty reproduces Check's per-tuple
+// commit decision without needing real meta types: when evaluateTuple drops one
+// of a tuple's tasks, the tuple's fingerprint must not be committed, so the next
+// tick still sees it as dirty instead of waiting on a full scan.
+func TestLeaderChecker_DroppedTaskKeepsTupleDirty(t *testing.T) {
+	c := &LeaderChecker{
+		tupleFingerprints:      make(map[leaderDirtyKey]uint64),
+		collectionFingerprints: make(map[int64]uint64),
+	}
+	key := leaderDirtyKey{CollectionID: 1, ReplicaID: 1, Channel: "ch-0"}
+	fp := uint64(42)
+
+	budget := newBudgetState(1, 0, 0)
+	_, dropped, _ := evaluateTuple([]task.Task{nil, nil}, key.CollectionID, key.ReplicaID, budget, func(task.Task) {})
+	if dropped == 0 {
+		c.commitTupleFingerprint(key, fp)
+	}
+
+	_, seen := c.tuplePrevFingerprint(key)
+	assert.False(t, seen, "a tuple with dropped tasks must not have its fingerprint committed")
+
+	// On the following tick, a clean run with no drops does commit it.
+	budget = newBudgetState(0, 0, 0)
+	_, dropped, _ = evaluateTuple([]task.Task{nil, nil}, key.CollectionID, key.ReplicaID, budget, func(task.Task) {})
+	if dropped == 0 {
+		c.commitTupleFingerprint(key, fp)
+	}
+	prev, seen := c.tuplePrevFingerprint(key)
+	assert.True(t, seen)
+	assert.Equal(t, fp, prev)
+}
+
+func TestRotateKeysToCursor(t *testing.T) {
+	keys := []leaderCheckUnitKey{
+		{collectionID: 1, replicaID: 1},
+		{collectionID: 1, replicaID: 2},
+		{collectionID: 2, replicaID: 3},
+	}
+
+	assert.Equal(t, 0, rotateKeysToCursor(keys, leaderBudgetCursor{}), "unset cursor starts from the beginning")
+	assert.Equal(t, 0, rotateKeysToCursor(nil, leaderBudgetCursor{valid: true, CollectionID: 1, ReplicaID: 1}), "empty keys always starts from 0")
+	assert.Equal(t, 0, rotateKeysToCursor(keys, leaderBudgetCursor{valid: true, CollectionID: 9, ReplicaID: 9}), "a cursor matching nothing starts from the beginning")
+	assert.Equal(t, 1, rotateKeysToCursor(keys, leaderBudgetCursor{valid: true, CollectionID: 1, ReplicaID: 1}))
+	assert.Equal(t, 0, rotateKeysToCursor(keys, leaderBudgetCursor{valid: true, CollectionID: 2, ReplicaID: 3}), "cursor on the last key wraps back to the start")
+}
@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+import (
+	"strconv"
+	"time"
+)
+
+// BaseTable is the flat key/value config source every ParamItem resolves
+// against: defaults seeded at startup, overridable by runtime config.
+type BaseTable struct {
+	values map[string]string
+}
+
+// NewBaseTable returns an empty BaseTable; callers get ParamItem defaults
+// until something overrides a key.
+func NewBaseTable() *BaseTable {
+	return &BaseTable{values: make(map[string]string)}
+}
+
+func (b *BaseTable) get(key, defaultValue string) string {
+	if v, ok := b.values[key]; ok {
+		return v
+	}
+	return defaultValue
+}
+
+// Save overrides key for the lifetime of the process, as a runtime config
+// update would.
+func (b *BaseTable) Save(key, value string) {
+	b.values[key] = value
+}
+
+// ParamItem is a single configuration entry. Init binds it to a BaseTable so
+// later Get* calls resolve through that table instead of always returning the
+// literal default.
+type ParamItem struct {
+	Key          string
+	Version      string
+	Doc          string
+	DefaultValue string
+	Export       bool
+
+	base *BaseTable
+}
+
+// Init binds the item to base. Must be called once before any Get* call.
+func (p *ParamItem) Init(base *BaseTable) {
+	p.base = base
+}
+
+// GetValue returns the item's current value as a string.
+func (p *ParamItem) GetValue() string {
+	return p.base.get(p.Key, p.DefaultValue)
+}
+
+// GetAsInt parses the current value as an int, returning 0 if it isn't one.
+func (p *ParamItem) GetAsInt() int {
+	v, err := strconv.Atoi(p.GetValue())
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// GetAsDuration parses the current value as an integer count of unit,
+// returning 0 if it isn't one.
+func (p *ParamItem) GetAsDuration(unit time.Duration) time.Duration {
+	v, err := strconv.ParseInt(p.GetValue(), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(v) * unit
+}
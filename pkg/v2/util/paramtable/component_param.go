@@ -0,0 +1,44 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+import "sync"
+
+// ComponentParam bundles every component's configuration behind a single
+// process-wide instance, so callers always reach it through Get() instead of
+// constructing their own.
+type ComponentParam struct {
+	once sync.Once
+	base *BaseTable
+
+	QueryCoordCfg QueryCoordConfig
+}
+
+var globalParams ComponentParam
+
+func (p *ComponentParam) init(base *BaseTable) {
+	p.base = base
+	p.QueryCoordCfg.init(base)
+}
+
+// Get returns the process-wide ComponentParam, initializing it on first use.
+func Get() *ComponentParam {
+	globalParams.once.Do(func() {
+		globalParams.init(NewBaseTable())
+	})
+	return &globalParams
+}
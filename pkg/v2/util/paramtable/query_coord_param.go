@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramtable
+
+// QueryCoordConfig groups the tunables QueryCoord's checkers read at runtime.
+type QueryCoordConfig struct {
+	LeaderViewFullScanInterval           ParamItem
+	LeaderCheckerTaskBudget              ParamItem
+	LeaderCheckerTaskBudgetPerCollection ParamItem
+	LeaderCheckerTaskBudgetPerReplica    ParamItem
+	LeaderCheckerPartStatsTaskPriority   ParamItem
+}
+
+func (p *QueryCoordConfig) init(base *BaseTable) {
+	p.LeaderViewFullScanInterval = ParamItem{
+		Key:          "queryCoord.leaderChecker.fullScanInterval",
+		Version:      "2.6.0",
+		DefaultValue: "60",
+		Doc:          "The interval, in seconds, at which LeaderChecker falls back to a full scan of every leader view even if nothing looks dirty.",
+		Export:       true,
+	}
+	p.LeaderViewFullScanInterval.Init(base)
+
+	p.LeaderCheckerTaskBudget = ParamItem{
+		Key:          "queryCoord.leaderChecker.taskBudget",
+		Version:      "2.6.0",
+		DefaultValue: "256",
+		Doc:          "The maximum number of leader tasks LeaderChecker may emit in a single Check pass. 0 or negative disables the limit.",
+		Export:       true,
+	}
+	p.LeaderCheckerTaskBudget.Init(base)
+
+	p.LeaderCheckerTaskBudgetPerCollection = ParamItem{
+		Key:          "queryCoord.leaderChecker.taskBudgetPerCollection",
+		Version:      "2.6.0",
+		DefaultValue: "64",
+		Doc:          "The maximum number of leader tasks LeaderChecker may emit for a single collection in one Check pass. 0 or negative disables the limit.",
+		Export:       true,
+	}
+	p.LeaderCheckerTaskBudgetPerCollection.Init(base)
+
+	p.LeaderCheckerTaskBudgetPerReplica = ParamItem{
+		Key:          "queryCoord.leaderChecker.taskBudgetPerReplica",
+		Version:      "2.6.0",
+		DefaultValue: "32",
+		Doc:          "The maximum number of leader tasks LeaderChecker may emit for a single replica in one Check pass. 0 or negative disables the limit.",
+		Export:       true,
+	}
+	p.LeaderCheckerTaskBudgetPerReplica.Init(base)
+
+	p.LeaderCheckerPartStatsTaskPriority = ParamItem{
+		Key:          "queryCoord.leaderChecker.partStatsTaskPriority",
+		Version:      "2.6.0",
+		DefaultValue: "0",
+		Doc:          "The task.TaskPriority LeaderChecker assigns to partition-stats sync tasks, kept independent of the priority tiers used for segment routing fixes.",
+		Export:       true,
+	}
+	p.LeaderCheckerPartStatsTaskPriority.Init(base)
+}